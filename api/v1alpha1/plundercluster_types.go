@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterFinalizer allows ReconcilePlunderCluster to clean up resources associated with PlunderCluster before
+	// removing it from the apiserver.
+	ClusterFinalizer = "plundercluster.infrastructure.cluster.x-k8s.io"
+)
+
+// PlunderClusterSpec defines the desired state of PlunderCluster
+type PlunderClusterSpec struct {
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+}
+
+// PlunderClusterStatus defines the observed state of PlunderCluster
+type PlunderClusterStatus struct {
+	// Ready denotes that the plunder cluster infrastructure is ready.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlunderCluster is the Schema for the plunderclusters API
+type PlunderCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PlunderClusterSpec   `json:"spec,omitempty"`
+	Status PlunderClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlunderClusterList contains a list of PlunderCluster
+type PlunderClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlunderCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PlunderCluster{}, &PlunderClusterList{})
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
+)
+
+const (
+	// MachineFinalizer allows ReconcilePlunderMachine to clean up resources associated with PlunderMachine before
+	// removing it from the apiserver.
+	MachineFinalizer = "plundermachine.infrastructure.cluster.x-k8s.io"
+)
+
+// PlunderMachineResourceStatus describes where a PlunderMachine is in its provisioning lifecycle,
+// loosely modelled on the Packet provider's PacketResourceStatus.
+type PlunderMachineResourceStatus string
+
+const (
+	// PlunderResourceStatusPending means the machine is waiting on a prerequisite, such as
+	// bootstrap data, before provisioning can begin.
+	PlunderResourceStatusPending PlunderMachineResourceStatus = "Pending"
+
+	// PlunderResourceStatusQueued means the machine is looking for hardware and, once found, has
+	// asked plunder to deploy an OS to it.
+	PlunderResourceStatusQueued PlunderMachineResourceStatus = "Queued"
+
+	// PlunderResourceStatusProvisioning means a host has been deployed and the bootstrap treasure
+	// map is being submitted to it.
+	PlunderResourceStatusProvisioning PlunderMachineResourceStatus = "Provisioning"
+
+	// PlunderResourceStatusBootstrapping means the bootstrap treasure map has been submitted and
+	// plunder is being polled for its completion.
+	PlunderResourceStatusBootstrapping PlunderMachineResourceStatus = "Bootstrapping"
+
+	// PlunderResourceStatusRunning means the machine has been provisioned and bootstrapped.
+	PlunderResourceStatusRunning PlunderMachineResourceStatus = "Running"
+
+	// PlunderResourceStatusFailed means provisioning could not complete; FailureReason and
+	// FailureMessage describe why.
+	PlunderResourceStatusFailed PlunderMachineResourceStatus = "Failed"
+)
+
+// Condition types for PlunderMachine, surfaced via Status.Conditions so users can tell why a
+// machine is stuck rather than only seeing an aggregate Ready bool.
+const (
+	// BootstrapDataAvailableCondition reports whether the owning Machine has published bootstrap
+	// data yet. Machines stay in the Pending phase until this is true.
+	BootstrapDataAvailableCondition clusterv1.ConditionType = "BootstrapDataAvailable"
+
+	// HardwareAvailableCondition reports whether hardware matching the machine's selector could be
+	// found in plunder's unleased pool.
+	HardwareAvailableCondition clusterv1.ConditionType = "HardwareAvailable"
+
+	// DeploymentCreatedCondition reports whether plunder accepted the deployment (preseed) request
+	// for the matched hardware.
+	DeploymentCreatedCondition clusterv1.ConditionType = "DeploymentCreated"
+
+	// OSProvisionedCondition reports plunder's progress installing the OS, as observed through the
+	// parlay log for the host.
+	OSProvisionedCondition clusterv1.ConditionType = "OSProvisioned"
+
+	// BootstrapExecutedCondition reports whether the bootstrap treasure map (write/chmod/execute of
+	// the kubeadm payload) has completed on the host.
+	BootstrapExecutedCondition clusterv1.ConditionType = "BootstrapExecuted"
+
+	// DrainingSucceededCondition reports whether the workload cluster node backing this machine has
+	// been cordoned and fully drained during deletion.
+	DrainingSucceededCondition clusterv1.ConditionType = "DrainingSucceeded"
+)
+
+// Reasons used alongside the condition types above.
+const (
+	// WaitingForBootstrapDataReason is used on BootstrapDataAvailableCondition while the owning
+	// Machine has not yet published Spec.Bootstrap.Data or DataSecretName.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+
+	// NoFreeHardwareReason is used on HardwareAvailableCondition when no host matches the
+	// machine's HardwareSelector/MACAddress.
+	NoFreeHardwareReason = "NoFreeHardware"
+
+	// DeploymentCreationFailedReason is used on DeploymentCreatedCondition when plunder's
+	// apiserver rejects the deployment request.
+	DeploymentCreationFailedReason = "DeploymentCreationFailed"
+
+	// OSProvisioningReason is used on OSProvisionedCondition while the parlay log reports the
+	// bootstrap treasure map is still running.
+	OSProvisioningReason = "OSProvisioning"
+
+	// BootstrapFailedReason is used on BootstrapExecutedCondition when the bootstrap treasure map
+	// fails on the host.
+	BootstrapFailedReason = "BootstrapFailed"
+
+	// NodeDrainingReason is used on DrainingSucceededCondition while pods are still being evicted
+	// from the node backing this machine.
+	NodeDrainingReason = "NodeDraining"
+)
+
+// PlunderMachineSpec defines the desired state of PlunderMachine
+type PlunderMachineSpec struct {
+	// ProviderID is the unique identifier as specified by the cloud provider.
+	// +optional
+	ProviderID *string `json:"providerID,omitempty"`
+
+	// HardwareSelector restricts provisioning to hosts whose labels in plunder's host inventory
+	// match every key/value pair given here. Leave empty to match any available hardware.
+	// +optional
+	HardwareSelector map[string]string `json:"hardwareSelector,omitempty"`
+
+	// MACAddress pins provisioning to a specific host rather than letting the reconciler pick any
+	// matching unleased hardware. Takes precedence over HardwareSelector.
+	// +optional
+	MACAddress *string `json:"macAddress,omitempty"`
+
+	// IPAddressPool names a plunder-managed address pool to allocate the install IP from, instead
+	// of using the matched host's own recorded IP address.
+	// +optional
+	IPAddressPool *string `json:"ipAddressPool,omitempty"`
+}
+
+// PlunderMachineStatus defines the observed state of PlunderMachine
+type PlunderMachineStatus struct {
+	// Ready denotes that the machine is provisioned and bootstrapped.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Phase represents the current phase of machine actuation.
+	// +optional
+	Phase PlunderMachineResourceStatus `json:"phase,omitempty"`
+
+	// InstalledMAC is the MAC address of the host selected for provisioning. It is recorded as
+	// soon as hardware is matched so that an in-flight provisioning can be recovered after a
+	// controller restart.
+	// +optional
+	InstalledMAC string `json:"installedMAC,omitempty"`
+
+	// InstallIP is the IP address plunder deployed the OS to.
+	// +optional
+	InstallIP string `json:"installIP,omitempty"`
+
+	// NodeDrained denotes that the drain step of deletion is finished: the workload cluster node
+	// backing this machine has been cordoned and drained, or Machine.Spec.NodeDrainTimeout passed
+	// first, in which case DrainingSucceededCondition is left false.
+	// +optional
+	NodeDrained bool `json:"nodeDrained,omitempty"`
+
+	// DrainingStartedAt records when the controller began draining the node, and is used to
+	// enforce Machine.Spec.NodeDrainTimeout.
+	// +optional
+	DrainingStartedAt *metav1.Time `json:"drainingStartedAt,omitempty"`
+
+	// Addresses lists the machine's known network addresses, surfaced for downstream Cluster API
+	// consumers.
+	// +optional
+	Addresses []clusterv1.MachineAddress `json:"addresses,omitempty"`
+
+	// ProvisioningStartedAt records when the machine entered the Queued phase, and is used to
+	// enforce the reconciler's provisioning timeout.
+	// +optional
+	ProvisioningStartedAt *metav1.Time `json:"provisioningStartedAt,omitempty"`
+
+	// FailureReason is a short machine-readable string describing why provisioning failed.
+	// +optional
+	FailureReason *string `json:"failureReason,omitempty"`
+
+	// FailureMessage is a human-readable description of why provisioning failed.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the PlunderMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// PlunderMachine is the Schema for the plundermachines API
+type PlunderMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PlunderMachineSpec   `json:"spec,omitempty"`
+	Status PlunderMachineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlunderMachineList contains a list of PlunderMachine
+type PlunderMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlunderMachine `json:"items"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (m *PlunderMachine) GetConditions() clusterv1.Conditions {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (m *PlunderMachine) SetConditions(conditions clusterv1.Conditions) {
+	m.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&PlunderMachine{}, &PlunderMachineList{})
+}
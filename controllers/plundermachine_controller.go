@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,13 +27,18 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrav1 "github.com/plunder-app/cluster-api-provider-plunder/api/v1alpha1"
 	"github.com/plunder-app/plunder/pkg/apiserver"
@@ -45,6 +51,15 @@ import (
 type PlunderMachineReconciler struct {
 	client.Client
 	Log logr.Logger
+
+	// ProvisioningTimeout bounds how long a machine may remain in the Queued, Provisioning or
+	// Bootstrapping phases before it is marked Failed. Defaults to DefaultProvisioningTimeout.
+	ProvisioningTimeout time.Duration
+
+	// WatchFilterValue, when set, restricts this controller to resources carrying a matching
+	// cluster.x-k8s.io/watch-filter label, so operators can shard reconciliation across multiple
+	// controller instances.
+	WatchFilterValue string
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=plundermachines,verbs=get;list;watch;create;update;patch;delete
@@ -132,97 +147,226 @@ func (r *PlunderMachineReconciler) Reconcile(req ctrl.Request) (_ ctrl.Result, r
 func (r *PlunderMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.PlunderMachine{}).
+		Watches(
+			&source.Kind{Type: &clusterv1.Machine{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: util.MachineToInfrastructureMapFunc(infrav1.GroupVersion.WithKind("PlunderMachine")),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &clusterv1.Cluster{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.clusterToPlunderMachines),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &infrav1.PlunderCluster{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.plunderClusterToPlunderMachines),
+			},
+		).
+		WithEventFilter(r.resourceNotPausedAndMatchesFilter(r.Log)).
 		Complete(r)
 }
 
+// DefaultProvisioningTimeout is used when the reconciler isn't explicitly configured with a
+// ProvisioningTimeout.
+const DefaultProvisioningTimeout = 20 * time.Minute
+
+// pollInterval is how often a Reconcile is requeued while waiting on an in-progress phase.
+const pollInterval = 10 * time.Second
+
 func (r *PlunderMachineReconciler) reconcileMachine(log logr.Logger, machine *clusterv1.Machine, plunderMachine *infrav1.PlunderMachine, cluster *clusterv1.Cluster, plunderCluster *infrav1.PlunderCluster) (_ ctrl.Result, reterr error) {
 	log.Info("Reconciling Machine")
-	// If the DockerMachine doesn't have finalizer, add it.
+	// Always aggregate the individual conditions into the top-level summary before this function
+	// returns, so it stays current on every return path, including early-outs and errors.
+	defer func() {
+		conditions.SetSummary(plunderMachine,
+			conditions.WithConditions(
+				infrav1.BootstrapDataAvailableCondition,
+				infrav1.HardwareAvailableCondition,
+				infrav1.DeploymentCreatedCondition,
+				infrav1.OSProvisionedCondition,
+				infrav1.BootstrapExecutedCondition,
+			),
+		)
+	}()
+
+	// If the PlunderMachine doesn't have finalizer, add it.
 	if !util.Contains(plunderMachine.Finalizers, infrav1.MachineFinalizer) {
 		plunderMachine.Finalizers = append(plunderMachine.Finalizers, infrav1.MachineFinalizer)
 	}
 
-	// Immeditaly give it the details it needs
-	//	providerID := "inception:////inception"
-
 	// if the machine is already provisioned, return
 	if plunderMachine.Spec.ProviderID != nil {
 		plunderMachine.Status.Ready = true
+		plunderMachine.Status.Phase = infrav1.PlunderResourceStatusRunning
+		return ctrl.Result{}, nil
+	}
+
+	// Each Reconcile call advances the state machine by at most one phase. The current phase (and
+	// the MAC/IP recorded against it) is persisted on Status, so a controller restart resumes from
+	// wherever the last successful patch left off instead of losing the in-flight provisioning.
+	switch plunderMachine.Status.Phase {
+	case infrav1.PlunderResourceStatusQueued, infrav1.PlunderResourceStatusProvisioning, infrav1.PlunderResourceStatusBootstrapping:
+		if plunderMachine.Status.ProvisioningStartedAt != nil && time.Since(plunderMachine.Status.ProvisioningStartedAt.Time) > r.provisioningTimeout() {
+			return r.failMachine(log, plunderMachine, fmt.Sprintf("provisioning did not reach %s within %s", infrav1.PlunderResourceStatusRunning, r.provisioningTimeout()))
+		}
+	}
 
+	switch plunderMachine.Status.Phase {
+	case infrav1.PlunderResourceStatusQueued:
+		return r.reconcileQueued(log, plunderMachine)
+	case infrav1.PlunderResourceStatusProvisioning:
+		return r.reconcileProvisioning(log, machine, plunderMachine)
+	case infrav1.PlunderResourceStatusBootstrapping:
+		return r.reconcileBootstrapping(log, plunderMachine)
+	case infrav1.PlunderResourceStatusFailed:
+		log.Info("Machine has failed provisioning, taking no further action")
 		return ctrl.Result{}, nil
+	default:
+		return r.reconcilePending(log, machine, plunderMachine)
 	}
+}
 
-	// Make sure bootstrap data is available and populated.
-	if machine.Spec.Bootstrap.Data == nil {
-		log.Info("The Plunder Provider currently doesn't require bootstrap data")
+// reconcilePending waits for bootstrap data to become available, then advances to Queued.
+func (r *PlunderMachineReconciler) reconcilePending(log logr.Logger, machine *clusterv1.Machine, plunderMachine *infrav1.PlunderMachine) (ctrl.Result, error) {
+	if machine.Spec.Bootstrap.Data == nil && machine.Spec.Bootstrap.DataSecretName == nil {
+		log.Info("Waiting for bootstrap data to be available")
+		conditions.MarkFalse(plunderMachine, infrav1.BootstrapDataAvailableCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "waiting for the owning Machine to publish bootstrap data")
+		plunderMachine.Status.Phase = infrav1.PlunderResourceStatusPending
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
 	}
+	conditions.MarkTrue(plunderMachine, infrav1.BootstrapDataAvailableCondition)
 
-	var installMAC string
+	now := metav1.Now()
+	plunderMachine.Status.ProvisioningStartedAt = &now
+	plunderMachine.Status.Phase = infrav1.PlunderResourceStatusQueued
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
 
-	// Find a machine for provisioning
+// reconcileQueued finds a host matching the machine's HardwareSelector (or MACAddress) and asks
+// plunder to deploy the OS to it.
+func (r *PlunderMachineReconciler) reconcileQueued(log logr.Logger, plunderMachine *infrav1.PlunderMachine) (ctrl.Result, error) {
 	u, c, err := apiserver.BuildEnvironmentFromConfig("plunderclient.yaml", "")
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+
+	spec := plunderMachine.Spec
+
+	// Fetch the currently unleased MACs, i.e. hardware that isn't already tied up with another
+	// deployment.
 	ep, resp := apiserver.FindFunctionEndpoint(u, c, "dhcp", http.MethodGet)
 	if resp.Error != "" {
 		return ctrl.Result{}, fmt.Errorf(resp.Error)
-
 	}
-
 	u.Path = path.Join(u.Path, ep.Path+"/unleased")
 
 	response, err := apiserver.ParsePlunderGet(u, c)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	// If an error has been returned then handle the error gracefully and terminate
 	if response.FriendlyError != "" || response.Error != "" {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, fmt.Errorf(response.Error)
 	}
 	var unleased []services.Lease
-
-	err = json.Unmarshal(response.Payload, &unleased)
-	if err != nil {
+	if err := json.Unmarshal(response.Payload, &unleased); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// Iterate through all known addresses and find a free one that looks "recent"
+	unleasedMACs := map[string]bool{}
 	for i := range unleased {
 		if time.Since(unleased[i].Expiry).Minutes() < 10 {
-			installMAC = unleased[i].Nic
+			unleasedMACs[unleased[i].Nic] = true
+		}
+	}
+
+	// Fetch the known host inventory so we can match against HardwareSelector labels and find each
+	// candidate's real IP address.
+	ep, resp = apiserver.FindFunctionEndpoint(u, c, "hosts", http.MethodGet)
+	if resp.Error != "" {
+		return ctrl.Result{}, fmt.Errorf(resp.Error)
+	}
+	u.Path = ep.Path
+
+	response, err = apiserver.ParsePlunderGet(u, c)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if response.FriendlyError != "" || response.Error != "" {
+		return ctrl.Result{}, fmt.Errorf(response.Error)
+	}
+	var hosts []services.Host
+	if err := json.Unmarshal(response.Payload, &hosts); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var chosen *services.Host
+	for i := range hosts {
+		host := &hosts[i]
+		if !unleasedMACs[host.MAC] {
+			continue
 		}
+		if spec.MACAddress != nil {
+			if host.MAC != *spec.MACAddress {
+				continue
+			}
+		} else if !hardwareLabelsMatch(spec.HardwareSelector, host.Labels) {
+			continue
+		}
+		chosen = host
+		break
+	}
+
+	// Hopefully we found one! Stay Queued and try again on the next reconcile.
+	if chosen == nil {
+		log.Info("No free hardware matches the requested selector, will retry")
+		conditions.MarkFalse(plunderMachine, infrav1.HardwareAvailableCondition, infrav1.NoFreeHardwareReason, clusterv1.ConditionSeverityWarning, "no unleased hardware matches the requested selector")
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
 	}
+	conditions.MarkTrue(plunderMachine, infrav1.HardwareAvailableCondition)
+
+	log.Info(fmt.Sprintf("Found Hardware %s (%s)", chosen.MAC, chosen.IP))
 
-	// Hopefully we found one!
-	if installMAC == "" {
-		return ctrl.Result{}, fmt.Errorf("No free hardware for provisioning")
+	installIP := chosen.IP
+	if spec.IPAddressPool != nil {
+		ep, resp = apiserver.FindFunctionEndpoint(u, c, "dhcp", http.MethodGet)
+		if resp.Error != "" {
+			return ctrl.Result{}, fmt.Errorf(resp.Error)
+		}
+		u.Path = path.Join(ep.Path, "pool", *spec.IPAddressPool)
+
+		response, err = apiserver.ParsePlunderGet(u, c)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if response.FriendlyError != "" || response.Error != "" {
+			return ctrl.Result{}, fmt.Errorf("failed to allocate from IP pool %s: %s", *spec.IPAddressPool, response.FriendlyError)
+		}
+		var allocated services.Lease
+		if err := json.Unmarshal(response.Payload, &allocated); err != nil {
+			return ctrl.Result{}, err
+		}
+		installIP = allocated.IP
 	}
 
-	log.Info(fmt.Sprintf("Found Hardware %s", installMAC))
+	if installIP == "" {
+		return ctrl.Result{}, fmt.Errorf("unable to determine an install IP address for hardware %s", chosen.MAC)
+	}
 
 	d := services.DeploymentConfig{
 		ConfigName: "preseed",
-		MAC:        installMAC,
+		MAC:        chosen.MAC,
 		ConfigHost: services.HostConfig{
-			IPAddress: "192.168.1.123",
+			IPAddress:  installIP,
+			ServerName: fmt.Sprintf("node-%s", StringWithCharset(5, charset)),
 		},
 	}
 
-	//Check the role of the machine
-	if util.IsControlPlaneMachine(machine) {
-		log.Info(fmt.Sprintf("Provisioning Control plane node %s", machine.Name))
-		d.ConfigHost.ServerName = fmt.Sprintf("controlplane-%s", StringWithCharset(5, charset))
-
-	} else {
-		log.Info(fmt.Sprintf("Provisioning Worker node %s", machine.Name))
-		d.ConfigHost.ServerName = fmt.Sprintf("worker-%s", StringWithCharset(5, charset))
-	}
-
 	ep, resp = apiserver.FindFunctionEndpoint(u, c, "deployment", http.MethodPost)
 	if resp.Error != "" {
 		return ctrl.Result{}, fmt.Errorf(resp.Error)
-
 	}
 
 	u.Path = ep.Path
@@ -237,105 +381,201 @@ func (r *PlunderMachineReconciler) reconcileMachine(log logr.Logger, machine *cl
 	}
 	// If an error has been returned then handle the error gracefully and terminate
 	if response.FriendlyError != "" || response.Error != "" {
+		conditions.MarkFalse(plunderMachine, infrav1.DeploymentCreatedCondition, infrav1.DeploymentCreationFailedReason, clusterv1.ConditionSeverityError, response.FriendlyError)
+		return ctrl.Result{}, fmt.Errorf(response.Error)
+	}
+	conditions.MarkTrue(plunderMachine, infrav1.DeploymentCreatedCondition)
+
+	plunderMachine.Status.InstalledMAC = chosen.MAC
+	plunderMachine.Status.InstallIP = installIP
+	plunderMachine.Status.Phase = infrav1.PlunderResourceStatusProvisioning
+	plunderMachine.Status.Addresses = []clusterv1.MachineAddress{
+		{Type: clusterv1.MachineInternalIP, Address: installIP},
+		{Type: clusterv1.MachineHostName, Address: d.ConfigHost.ServerName},
+	}
+
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// hardwareLabelsMatch reports whether host carries every key/value pair in selector. An empty
+// selector matches any host.
+func hardwareLabelsMatch(selector map[string]string, hostLabels map[string]string) bool {
+	for k, v := range selector {
+		if hostLabels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileProvisioning submits the bootstrap treasure map once the host has been deployed.
+func (r *PlunderMachineReconciler) reconcileProvisioning(log logr.Logger, machine *clusterv1.Machine, plunderMachine *infrav1.PlunderMachine) (ctrl.Result, error) {
+	bootstrapData, err := r.getBootstrapData(machine)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	u, c, err := apiserver.BuildEnvironmentFromConfig("plunderclient.yaml", "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newMap := bootstrapCommand(plunderMachine.Status.InstallIP, bootstrapData)
+
+	b, err := json.Marshal(newMap)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	ep, resp := apiserver.FindFunctionEndpoint(u, c, "parlay", http.MethodPost)
+	if resp.Error != "" {
 		return ctrl.Result{}, fmt.Errorf(resp.Error)
+	}
+	u.Path = ep.Path
 
+	response, err := apiserver.ParsePlunderPost(u, c, b)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	// If an error has been returned then handle the error gracefully and terminate
+	if response.FriendlyError != "" || response.Error != "" {
+		return ctrl.Result{}, fmt.Errorf(response.Error)
+	}
+
+	log.Info(fmt.Sprintf("Submitted bootstrap treasure map for %s", plunderMachine.Status.InstallIP))
+	conditions.MarkFalse(plunderMachine, infrav1.OSProvisionedCondition, infrav1.OSProvisioningReason, clusterv1.ConditionSeverityInfo, "waiting for the bootstrap treasure map to complete")
+	plunderMachine.Status.Phase = infrav1.PlunderResourceStatusBootstrapping
 
-	newMap := uptimeCommand(d.ConfigHost.IPAddress)
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
 
-	// Marshall the parlay submission (runs the uptime command)
-	b, err = json.Marshal(newMap)
+// reconcileBootstrapping polls plunder's parlay log until the bootstrap treasure map completes.
+func (r *PlunderMachineReconciler) reconcileBootstrapping(log logr.Logger, plunderMachine *infrav1.PlunderMachine) (ctrl.Result, error) {
+	u, c, err := apiserver.BuildEnvironmentFromConfig("plunderclient.yaml", "")
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// Create the string that will be used to get the logs
-	dashAddress := strings.Replace(d.ConfigHost.IPAddress, ".", "-", -1)
+	// Recreate the string used to look up the logs; this is derived purely from Status, so it
+	// survives a controller restart.
+	dashAddress := strings.Replace(plunderMachine.Status.InstallIP, ".", "-", -1)
 
-	// Get the time
-	t := time.Now()
+	ep, resp := apiserver.FindFunctionEndpoint(u, c, "parlayLog", http.MethodGet)
+	if resp.Error != "" {
+		return ctrl.Result{}, fmt.Errorf(resp.Error)
+	}
+	u.Path = ep.Path + "/" + dashAddress
 
-	for {
-		// Set Parlay API path and POST
-		ep, resp = apiserver.FindFunctionEndpoint(u, c, "parlay", http.MethodPost)
-		if resp.Error != "" {
-			return ctrl.Result{}, fmt.Errorf(resp.Error)
+	response, err := apiserver.ParsePlunderGet(u, c)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// If an error has been returned then handle the error gracefully and terminate
+	if response.FriendlyError != "" || response.Error != "" {
+		return ctrl.Result{}, fmt.Errorf(response.Error)
+	}
 
-		}
-		u.Path = ep.Path
+	var logs plunderlogging.JSONLog
+	err = json.Unmarshal(response.Payload, &logs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
-		response, err := apiserver.ParsePlunderPost(u, c, b)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+	switch logs.State {
+	case "Completed":
+		log.Info(fmt.Sprintf("Host %s has been successfully bootstrapped", plunderMachine.Status.InstallIP))
+		conditions.MarkTrue(plunderMachine, infrav1.OSProvisionedCondition)
+		conditions.MarkTrue(plunderMachine, infrav1.BootstrapExecutedCondition)
 
-		// If an error has been returned then handle the error gracefully and terminate
-		if response.FriendlyError != "" || response.Error != "" {
-			return ctrl.Result{}, fmt.Errorf(resp.Error)
+		providerID := fmt.Sprintf("plunder://%s", plunderMachine.Status.InstalledMAC)
+		plunderMachine.Spec.ProviderID = &providerID
+		plunderMachine.Status.Ready = true
+		plunderMachine.Status.Phase = infrav1.PlunderResourceStatusRunning
 
-		}
+		return ctrl.Result{}, nil
+	case "Failed":
+		conditions.MarkFalse(plunderMachine, infrav1.BootstrapExecutedCondition, infrav1.BootstrapFailedReason, clusterv1.ConditionSeverityError, "bootstrap treasure map failed")
+		return r.failMachine(log, plunderMachine, fmt.Sprintf("bootstrap treasure map failed for %s", plunderMachine.Status.InstallIP))
+	default:
+		return ctrl.Result{RequeueAfter: pollInterval}, nil
+	}
+}
 
-		// Sleep for five seconds
-		time.Sleep(5 * time.Second)
+// failMachine moves the PlunderMachine to the terminal Failed phase and records why, so Cluster
+// API's Machine controller can react (e.g. by deleting and recreating it).
+func (r *PlunderMachineReconciler) failMachine(log logr.Logger, plunderMachine *infrav1.PlunderMachine, message string) (ctrl.Result, error) {
+	log.Info(fmt.Sprintf("Marking machine Failed: %s", message))
+	reason := "ProvisioningFailed"
+	plunderMachine.Status.Phase = infrav1.PlunderResourceStatusFailed
+	plunderMachine.Status.FailureReason = &reason
+	plunderMachine.Status.FailureMessage = &message
+	return ctrl.Result{}, nil
+}
 
-		// Set the parlay API get logs path and GET
-		ep, resp = apiserver.FindFunctionEndpoint(u, c, "parlayLog", http.MethodGet)
-		if resp.Error != "" {
-			return ctrl.Result{}, fmt.Errorf(resp.Error)
+// provisioningTimeout returns the configured provisioning timeout, falling back to
+// DefaultProvisioningTimeout when the reconciler wasn't set up with one.
+func (r *PlunderMachineReconciler) provisioningTimeout() time.Duration {
+	if r.ProvisioningTimeout == 0 {
+		return DefaultProvisioningTimeout
+	}
+	return r.ProvisioningTimeout
+}
+
+func (r *PlunderMachineReconciler) reconcileMachineDelete(logger logr.Logger, machine *clusterv1.Machine, plunderMachine *infrav1.PlunderMachine, cluster *clusterv1.Cluster, plunderCluster *infrav1.PlunderCluster) (_ ctrl.Result, reterr error) {
+	logger.Info("Deleting Machine")
 
+	// Cordon and drain the workload cluster node before we start tearing down the underlying
+	// hardware, so running pods get a chance to shut down cleanly elsewhere.
+	if !plunderMachine.Status.NodeDrained {
+		if plunderMachine.Status.DrainingStartedAt == nil {
+			now := metav1.Now()
+			plunderMachine.Status.DrainingStartedAt = &now
 		}
-		u.Path = ep.Path + "/" + dashAddress
 
-		response, err = apiserver.ParsePlunderGet(u, c)
+		drained, err := r.drainNode(logger, cluster, machine)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		// If an error has been returned then handle the error gracefully and terminate
-		if response.FriendlyError != "" || response.Error != "" {
-			return ctrl.Result{}, fmt.Errorf(resp.Error)
 
+		timedOut := time.Since(plunderMachine.Status.DrainingStartedAt.Time) > nodeDrainTimeout(machine)
+		if !drained && !timedOut {
+			conditions.MarkFalse(plunderMachine, infrav1.DrainingSucceededCondition, infrav1.NodeDrainingReason, clusterv1.ConditionSeverityInfo, "waiting for pods to finish evicting from the node")
+			return ctrl.Result{RequeueAfter: pollInterval}, nil
 		}
+		if drained {
+			conditions.MarkTrue(plunderMachine, infrav1.DrainingSucceededCondition)
+		} else {
+			logger.Info("Node drain timeout exceeded, proceeding with deletion anyway")
+			conditions.MarkFalse(plunderMachine, infrav1.DrainingSucceededCondition, infrav1.NodeDrainingReason, clusterv1.ConditionSeverityWarning, "node drain timeout exceeded, proceeding with deletion")
+		}
+		plunderMachine.Status.NodeDrained = true
+	}
 
-		var logs plunderlogging.JSONLog
-
-		err = json.Unmarshal(response.Payload, &logs)
-		if err != nil {
+	// Tear down the plunder-side resources. Both calls are idempotent, so retrying a partially
+	// failed delete is safe.
+	if plunderMachine.Status.InstalledMAC != "" {
+		if err := r.deleteDeployment(logger, plunderMachine.Status.InstalledMAC); err != nil {
 			return ctrl.Result{}, err
 		}
 
-		if logs.State == "Completed" {
-			log.Info(fmt.Sprintf("Host has been succesfully provisioned OS in %s Seconds\n", time.Since(t).Round(time.Second)))
-			break
+		if err := r.releaseLease(logger, plunderMachine.Status.InstalledMAC); err != nil {
+			return ctrl.Result{}, err
 		}
 	}
 
-	// TODO - Attempt to create the machine
-
-	// // if the machine is a control plane added, update the load balancer configuration
-	// if util.IsControlPlaneMachine(machine) {}
-
-	// DEPLOY THE MACHINE
-	//clusterDeploy(nil)
-
-	providerID := fmt.Sprintf("plunder://%s", installMAC)
-
-	plunderMachine.Spec.ProviderID = &providerID
-	// Mark the inceptionMachine ready
-	plunderMachine.Status.Ready = true
-
-	return ctrl.Result{}, nil
-
-}
-
-func (r *PlunderMachineReconciler) reconcileMachineDelete(logger logr.Logger, machine *clusterv1.Machine, inceptionMachine *infrav1.PlunderMachine, cluster *clusterv1.Cluster, inceptionCluster *infrav1.PlunderCluster) (_ ctrl.Result, reterr error) {
-	logger.Info("Deleting Machine")
 	// Machine is deleted so remove the finalizer.
-	inceptionMachine.Finalizers = util.Filter(inceptionMachine.Finalizers, infrav1.MachineFinalizer)
+	plunderMachine.Finalizers = util.Filter(plunderMachine.Finalizers, infrav1.MachineFinalizer)
 	return ctrl.Result{}, nil
 
 }
 
-func uptimeCommand(host string) parlaytypes.TreasureMap {
+// bootstrapScriptPath is where the decoded bootstrap payload is written on the target host before
+// it is executed.
+const bootstrapScriptPath = "/run/cluster-api/bootstrap.sh"
+
+// bootstrapCommand builds the parlay TreasureMap that writes the bootstrap data to the host,
+// marks it executable, and runs it.
+func bootstrapCommand(host string, bootstrapData string) parlaytypes.TreasureMap {
 	return parlaytypes.TreasureMap{
 		Deployments: []parlaytypes.Deployment{
 			parlaytypes.Deployment{
@@ -345,11 +585,52 @@ func uptimeCommand(host string) parlaytypes.TreasureMap {
 				Actions: []parlaytypes.Action{
 					parlaytypes.Action{
 						ActionType: "command",
-						Command:    "uptime",
-						Name:       "Cluster-API provisioning uptime command",
+						Command:    fmt.Sprintf("cat <<'PLUNDER_BOOTSTRAP_EOF' > %s\n%s\nPLUNDER_BOOTSTRAP_EOF", bootstrapScriptPath, bootstrapData),
+						Name:       "Write bootstrap script",
+					},
+					parlaytypes.Action{
+						ActionType: "command",
+						Command:    fmt.Sprintf("chmod +x %s", bootstrapScriptPath),
+						Name:       "Make bootstrap script executable",
+					},
+					parlaytypes.Action{
+						ActionType: "command",
+						Command:    bootstrapScriptPath,
+						Name:       "Execute bootstrap script",
 					},
 				},
 			},
 		},
 	}
 }
+
+// getBootstrapData returns the decoded bootstrap data (cloud-init / kubeadm join payload) for the
+// given Machine, fetching it from the referenced Secret when DataSecretName is used, falling back
+// to the inline, base64-encoded Data field for older Cluster API versions.
+func (r *PlunderMachineReconciler) getBootstrapData(machine *clusterv1.Machine) (string, error) {
+	if machine.Spec.Bootstrap.DataSecretName != nil {
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: machine.Namespace, Name: *machine.Spec.Bootstrap.DataSecretName}
+		if err := r.Client.Get(context.Background(), key, secret); err != nil {
+			return "", fmt.Errorf("failed to retrieve bootstrap data secret %s: %v", key, err)
+		}
+
+		value, ok := secret.Data["value"]
+		if !ok {
+			return "", fmt.Errorf("bootstrap data secret %s has no 'value' key", key)
+		}
+
+		return string(value), nil
+	}
+
+	if machine.Spec.Bootstrap.Data == nil {
+		return "", fmt.Errorf("machine %s has no bootstrap data", machine.Name)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(*machine.Spec.Bootstrap.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode bootstrap data: %v", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProvisioningTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		reconciler PlunderMachineReconciler
+		want       time.Duration
+	}{
+		{
+			name:       "defaults when unset",
+			reconciler: PlunderMachineReconciler{},
+			want:       DefaultProvisioningTimeout,
+		},
+		{
+			name:       "uses the configured value",
+			reconciler: PlunderMachineReconciler{ProvisioningTimeout: 5 * time.Minute},
+			want:       5 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reconciler.provisioningTimeout(); got != tt.want {
+				t.Errorf("provisioningTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
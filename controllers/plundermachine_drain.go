@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+)
+
+// DefaultNodeDrainTimeout is used when the Machine doesn't specify Spec.NodeDrainTimeout.
+const DefaultNodeDrainTimeout = 10 * time.Minute
+
+// drainNode cordons and evicts all evictable pods from the Machine's node, mirroring the drain
+// behaviour of CAPI's core Machine controller. It returns true once the node has no more pods left
+// to evict (or there is no NodeRef yet, in which case there is nothing to drain).
+func (r *PlunderMachineReconciler) drainNode(log logr.Logger, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (bool, error) {
+	if machine.Status.NodeRef == nil {
+		log.Info("Machine has no NodeRef, nothing to drain")
+		return true, nil
+	}
+
+	clientset, err := remote.NewClusterClient(r.Client, cluster)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workload cluster client: %v", err)
+	}
+
+	nodeName := machine.Status.NodeRef.Name
+
+	node, err := clientset.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Node %s no longer exists, nothing to drain", nodeName))
+			return true, nil
+		}
+		return false, err
+	}
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if _, err := clientset.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{}); err != nil {
+			return false, fmt.Errorf("failed to cordon node %s: %v", nodeName, err)
+		}
+		log.Info(fmt.Sprintf("Cordoned node %s", nodeName))
+	}
+
+	pods, err := clientset.CoreV1().Pods(corev1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list pods on node %s: %v", nodeName, err)
+	}
+
+	pending := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			pending++
+			continue
+		}
+		if isMirrorOrDaemonSetPod(pod) {
+			continue
+		}
+
+		pending++
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		}
+		if err := clientset.PolicyV1beta1().Evictions(pod.Namespace).Evict(context.Background(), eviction); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	if pending > 0 {
+		log.Info(fmt.Sprintf("Waiting for %d pod(s) to finish evicting from node %s", pending, nodeName))
+		return false, nil
+	}
+
+	log.Info(fmt.Sprintf("Drained node %s", nodeName))
+	return true, nil
+}
+
+// isMirrorOrDaemonSetPod reports whether a pod should be left in place by the drain, matching the
+// exclusions CAPI's core Machine controller applies.
+func isMirrorOrDaemonSetPod(pod *corev1.Pod) bool {
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return true
+	}
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeDrainTimeout returns the Machine's configured drain timeout, falling back to
+// DefaultNodeDrainTimeout when unset.
+func nodeDrainTimeout(machine *clusterv1.Machine) time.Duration {
+	if machine.Spec.NodeDrainTimeout == nil {
+		return DefaultNodeDrainTimeout
+	}
+	return machine.Spec.NodeDrainTimeout.Duration
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
+)
+
+func TestIsMirrorOrDaemonSetPod(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{
+			name: "regular pod",
+			pod:  &corev1.Pod{},
+			want: false,
+		},
+		{
+			name: "mirror pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{corev1.MirrorPodAnnotationKey: ""},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "daemonset pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet"}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "owned by something else",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet"}},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isMirrorOrDaemonSetPod(tt.pod); got != tt.want {
+				t.Errorf("isMirrorOrDaemonSetPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeDrainTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		machine *clusterv1.Machine
+		want    time.Duration
+	}{
+		{
+			name:    "defaults when unset",
+			machine: &clusterv1.Machine{},
+			want:    DefaultNodeDrainTimeout,
+		},
+		{
+			name: "uses the configured value",
+			machine: &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					NodeDrainTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+				},
+			},
+			want: 2 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeDrainTimeout(tt.machine); got != tt.want {
+				t.Errorf("nodeDrainTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
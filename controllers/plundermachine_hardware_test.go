@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import "testing"
+
+func TestHardwareLabelsMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		selector   map[string]string
+		hostLabels map[string]string
+		want       bool
+	}{
+		{
+			name:       "empty selector matches any host",
+			selector:   nil,
+			hostLabels: map[string]string{"zone": "a"},
+			want:       true,
+		},
+		{
+			name:       "matches when every key/value pair is present",
+			selector:   map[string]string{"zone": "a"},
+			hostLabels: map[string]string{"zone": "a", "rack": "1"},
+			want:       true,
+		},
+		{
+			name:       "fails when a value differs",
+			selector:   map[string]string{"zone": "a"},
+			hostLabels: map[string]string{"zone": "b"},
+			want:       false,
+		},
+		{
+			name:       "fails when a key is missing",
+			selector:   map[string]string{"zone": "a", "rack": "1"},
+			hostLabels: map[string]string{"zone": "a"},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hardwareLabelsMatch(tt.selector, tt.hostLabels); got != tt.want {
+				t.Errorf("hardwareLabelsMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
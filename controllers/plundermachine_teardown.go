@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/plunder-app/plunder/pkg/apiserver"
+)
+
+// deleteDeployment tells plunder to stop serving the preseed/deployment configuration for the
+// given MAC. It is idempotent: a "not found" style response from plunder (the expected outcome of
+// retrying a delete that already succeeded) is treated as success rather than a hard error.
+func (r *PlunderMachineReconciler) deleteDeployment(log logr.Logger, mac string) error {
+	u, c, err := apiserver.BuildEnvironmentFromConfig("plunderclient.yaml", "")
+	if err != nil {
+		return err
+	}
+
+	ep, resp := apiserver.FindFunctionEndpoint(u, c, "deployment", http.MethodDelete)
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+	u.Path = path.Join(u.Path, ep.Path, mac)
+
+	response, err := apiserver.ParsePlunderDelete(u, c)
+	if err != nil {
+		return err
+	}
+	if response.FriendlyError != "" || response.Error != "" {
+		if isNotFoundResponse(response.FriendlyError, response.Error) {
+			log.Info(fmt.Sprintf("Plunder deployment for %s is already gone", mac))
+			return nil
+		}
+		return fmt.Errorf(response.Error)
+	}
+
+	log.Info(fmt.Sprintf("Removed plunder deployment for %s", mac))
+	return nil
+}
+
+// releaseLease frees the DHCP lease held for the given MAC so it can be picked up as unleased
+// hardware again. It is idempotent: a "not found" style response from plunder (the expected
+// outcome of retrying a release that already succeeded) is treated as success rather than a hard
+// error.
+func (r *PlunderMachineReconciler) releaseLease(log logr.Logger, mac string) error {
+	u, c, err := apiserver.BuildEnvironmentFromConfig("plunderclient.yaml", "")
+	if err != nil {
+		return err
+	}
+
+	ep, resp := apiserver.FindFunctionEndpoint(u, c, "dhcp", http.MethodDelete)
+	if resp.Error != "" {
+		return fmt.Errorf(resp.Error)
+	}
+	u.Path = path.Join(u.Path, ep.Path, "lease", mac)
+
+	response, err := apiserver.ParsePlunderDelete(u, c)
+	if err != nil {
+		return err
+	}
+	if response.FriendlyError != "" || response.Error != "" {
+		if isNotFoundResponse(response.FriendlyError, response.Error) {
+			log.Info(fmt.Sprintf("DHCP lease for %s is already gone", mac))
+			return nil
+		}
+		return fmt.Errorf(response.Error)
+	}
+
+	log.Info(fmt.Sprintf("Released DHCP lease for %s", mac))
+	return nil
+}
+
+// isNotFoundResponse reports whether a plunder error response describes a resource that is
+// already gone. The plunder apiserver doesn't expose a typed not-found error, so this matches on
+// the message text, same as the other delete endpoints report it.
+func isNotFoundResponse(friendlyError, apiError string) bool {
+	return strings.Contains(strings.ToLower(friendlyError), "not found") ||
+		strings.Contains(strings.ToLower(apiError), "not found")
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1alpha2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/plunder-app/cluster-api-provider-plunder/api/v1alpha1"
+)
+
+// watchFilterLabel lets an operator shard PlunderMachine reconciliation across multiple
+// controller instances, each watching only resources carrying a matching value.
+const watchFilterLabel = "cluster.x-k8s.io/watch-filter"
+
+// clusterToPlunderMachines maps a Cluster event (e.g. Status.InfrastructureReady flipping) to a
+// reconcile request for every PlunderMachine belonging to that cluster.
+func (r *PlunderMachineReconciler) clusterToPlunderMachines(o handler.MapObject) []reconcile.Request {
+	cluster, ok := o.Object.(*clusterv1.Cluster)
+	if !ok {
+		return nil
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(context.Background(), machineList,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.MachineClusterLabelName: cluster.Name},
+	); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(machineList.Items))
+	for i := range machineList.Items {
+		m := &machineList.Items[i]
+		if m.Spec.InfrastructureRef.Name == "" || m.Spec.InfrastructureRef.Kind != "PlunderMachine" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: m.Namespace, Name: m.Spec.InfrastructureRef.Name},
+		})
+	}
+	return requests
+}
+
+// plunderClusterToPlunderMachines maps a PlunderCluster event to every PlunderMachine owned by its
+// Cluster, via clusterToPlunderMachines.
+func (r *PlunderMachineReconciler) plunderClusterToPlunderMachines(o handler.MapObject) []reconcile.Request {
+	plunderCluster, ok := o.Object.(*infrav1.PlunderCluster)
+	if !ok {
+		return nil
+	}
+
+	cluster, err := util.GetOwnerCluster(context.Background(), r.Client, plunderCluster.ObjectMeta)
+	if err != nil || cluster == nil {
+		return nil
+	}
+
+	return r.clusterToPlunderMachines(handler.MapObject{Object: cluster})
+}
+
+// resourceNotPausedAndMatchesFilter builds the event filter applied to every watch: it drops
+// events for resources that themselves carry the cluster.x-k8s.io/paused annotation, and, when
+// WatchFilterValue is set, events for resources that don't carry a matching watchFilterLabel.
+// Only the annotation on the event's own object is checked; the owning Cluster's pause state is
+// not looked up.
+func (r *PlunderMachineReconciler) resourceNotPausedAndMatchesFilter(log logr.Logger) predicate.Funcs {
+	process := func(obj metav1.Object) bool {
+		if r.WatchFilterValue != "" && obj.GetLabels()[watchFilterLabel] != r.WatchFilterValue {
+			return false
+		}
+		if obj.GetAnnotations()[clusterv1.PausedAnnotation] == "true" {
+			log.V(4).Info("Resource is paused, will not attempt to map or enqueue", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			return false
+		}
+		return true
+	}
+
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return process(e.Meta) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return process(e.MetaNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return process(e.Meta) },
+		GenericFunc: func(e event.GenericEvent) bool { return process(e.Meta) },
+	}
+}